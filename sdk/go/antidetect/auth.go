@@ -0,0 +1,135 @@
+package antidetect
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+// Authenticator applies credentials to an outgoing request. Implement this
+// to plug in mTLS, HMAC-signed requests, OAuth2 token sources, short-lived
+// JWTs, or anything else beyond the built-in static bearer token set by
+// WithAPIKey.
+type Authenticator interface {
+	Apply(req *http.Request) error
+}
+
+// RefreshableAuthenticator is an Authenticator that can reissue its
+// credentials on demand. If the configured Authenticator implements this,
+// the client will call Refresh and retry the original request once after
+// a 401 response.
+type RefreshableAuthenticator interface {
+	Authenticator
+	Refresh(ctx context.Context) error
+}
+
+// WithAuthenticator sets the Authenticator used to sign every outgoing
+// request, replacing any API key set via WithAPIKey.
+func WithAuthenticator(auth Authenticator) ClientOption {
+	return func(c *Client) {
+		c.authenticator = auth
+	}
+}
+
+// apiKeyAuthenticator is the Authenticator installed by WithAPIKey.
+type apiKeyAuthenticator struct {
+	apiKey string
+}
+
+func (a apiKeyAuthenticator) Apply(req *http.Request) error {
+	req.Header.Set("Authorization", "Bearer "+a.apiKey)
+	return nil
+}
+
+// TokenAuthenticator is a RefreshableAuthenticator backed by a
+// caller-supplied fetch function (e.g. an OAuth2 token source). It issues
+// a token on first use and automatically reissues it when the client
+// retries after a 401.
+type TokenAuthenticator struct {
+	fetch func(ctx context.Context) (string, error)
+
+	mu    sync.Mutex
+	token string
+}
+
+// NewTokenAuthenticator returns a TokenAuthenticator that calls fetch to
+// obtain a bearer token, lazily on first use and again whenever the
+// server rejects a request with 401.
+func NewTokenAuthenticator(fetch func(ctx context.Context) (string, error)) *TokenAuthenticator {
+	return &TokenAuthenticator{fetch: fetch}
+}
+
+// Apply sets the Authorization header, fetching an initial token if none
+// has been issued yet.
+func (a *TokenAuthenticator) Apply(req *http.Request) error {
+	a.mu.Lock()
+	token := a.token
+	a.mu.Unlock()
+
+	if token == "" {
+		if err := a.Refresh(req.Context()); err != nil {
+			return err
+		}
+		a.mu.Lock()
+		token = a.token
+		a.mu.Unlock()
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	return nil
+}
+
+// Refresh reissues the token by calling fetch again.
+func (a *TokenAuthenticator) Refresh(ctx context.Context) error {
+	token, err := a.fetch(ctx)
+	if err != nil {
+		return fmt.Errorf("token authenticator: refresh failed: %w", err)
+	}
+	a.mu.Lock()
+	a.token = token
+	a.mu.Unlock()
+	return nil
+}
+
+// WithMTLS configures the client to authenticate to the API with a client
+// TLS certificate, verifying the server against caPool (or the system
+// pool if nil). If combined with WithHTTPClient, either apply WithMTLS
+// after it, or pass an httpClient with Transport left nil so WithHTTPClient
+// carries the configured transport over; applying WithMTLS first with a
+// non-nil httpClient.Transport discards the TLS settings.
+func WithMTLS(cert tls.Certificate, caPool *x509.CertPool) ClientOption {
+	return func(c *Client) {
+		transport := cloneTransport(c.httpClient.Transport)
+		transport.TLSClientConfig = &tls.Config{
+			Certificates: []tls.Certificate{cert},
+			RootCAs:      caPool,
+		}
+		c.httpClient.Transport = transport
+	}
+}
+
+// WithTransport sets the *http.Transport used for outgoing requests, so
+// callers can configure proxying, dial timeouts, or connection pooling
+// without building an entire *http.Client via WithHTTPClient. If combined
+// with WithHTTPClient, either apply WithTransport after it, or pass an
+// httpClient with Transport left nil so WithHTTPClient carries it over;
+// applying WithTransport first with a non-nil httpClient.Transport
+// discards it.
+func WithTransport(transport *http.Transport) ClientOption {
+	return func(c *Client) {
+		c.httpClient.Transport = transport
+	}
+}
+
+// cloneTransport returns t as an *http.Transport, cloning it so callers
+// don't mutate a shared transport, or a clone of http.DefaultTransport if
+// t is nil or not an *http.Transport.
+func cloneTransport(t http.RoundTripper) *http.Transport {
+	if transport, ok := t.(*http.Transport); ok {
+		return transport.Clone()
+	}
+	return http.DefaultTransport.(*http.Transport).Clone()
+}
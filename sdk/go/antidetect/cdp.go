@@ -0,0 +1,282 @@
+package antidetect
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// Attach dials the WebSocket endpoint of a running session and returns a
+// SessionDriver that speaks the Chrome DevTools Protocol directly, for
+// callers that need finer-grained control than Navigate/Execute provide
+// (page lifecycle events, network interception, DOM queries, screenshots).
+func (s *SessionsService) Attach(ctx context.Context, sessionID string) (*SessionDriver, error) {
+	sess, err := s.Get(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("attach: failed to load session: %w", err)
+	}
+	if sess.WSEndpoint == "" {
+		return nil, fmt.Errorf("attach: session %s has no WSEndpoint", sessionID)
+	}
+	return DialSessionDriver(ctx, sess.WSEndpoint)
+}
+
+// SessionDriver is a raw Chrome DevTools Protocol connection to a launched
+// browser session. It is safe for concurrent use.
+type SessionDriver struct {
+	wsEndpoint string
+	conn       *websocket.Conn
+	writeMu    sync.Mutex // guards conn.WriteJSON; gorilla/websocket allows only one writer
+
+	nextID  int64
+	pending sync.Map // map[int64]chan cdpResult
+
+	eventsMu sync.RWMutex
+	events   map[string][]chan json.RawMessage
+
+	closeOnce sync.Once
+	closed    chan struct{}
+}
+
+type cdpResult struct {
+	result json.RawMessage
+	err    *cdpError
+}
+
+type cdpError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *cdpError) Error() string {
+	return fmt.Sprintf("cdp error %d: %s", e.Code, e.Message)
+}
+
+type cdpMessage struct {
+	ID     int64           `json:"id,omitempty"`
+	Method string          `json:"method,omitempty"`
+	Params json.RawMessage `json:"params,omitempty"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  *cdpError       `json:"error,omitempty"`
+}
+
+// RawWSEndpoint returns the underlying WebSocket debugger URL, so callers
+// that prefer chromedp (or any other CDP client) can hand it off directly
+// instead of going through SessionDriver:
+//
+//	ctx, cancel := chromedp.NewRemoteAllocator(ctx, driver.RawWSEndpoint())
+func (d *SessionDriver) RawWSEndpoint() string {
+	return d.wsEndpoint
+}
+
+// DialSessionDriver dials a raw CDP WebSocket endpoint directly. Most
+// callers should use SessionsService.Attach instead.
+func DialSessionDriver(ctx context.Context, wsEndpoint string) (*SessionDriver, error) {
+	dialer := websocket.Dialer{HandshakeTimeout: 10 * time.Second}
+	conn, _, err := dialer.DialContext(ctx, wsEndpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("cdp dial: %w", err)
+	}
+
+	d := &SessionDriver{
+		wsEndpoint: wsEndpoint,
+		conn:       conn,
+		events:     make(map[string][]chan json.RawMessage),
+		closed:     make(chan struct{}),
+	}
+	go d.readLoop()
+	return d, nil
+}
+
+func (d *SessionDriver) readLoop() {
+	defer close(d.closed)
+	for {
+		_, data, err := d.conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		var msg cdpMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			continue
+		}
+		if msg.Method != "" {
+			d.dispatchEvent(msg.Method, msg.Params)
+			continue
+		}
+		if ch, ok := d.pending.LoadAndDelete(msg.ID); ok {
+			ch.(chan cdpResult) <- cdpResult{result: msg.Result, err: msg.Error}
+		}
+	}
+}
+
+func (d *SessionDriver) dispatchEvent(method string, params json.RawMessage) {
+	d.eventsMu.RLock()
+	subs := d.events[method]
+	d.eventsMu.RUnlock()
+	for _, ch := range subs {
+		select {
+		case ch <- params:
+		default:
+		}
+	}
+}
+
+// On subscribes to a CDP event (e.g. "Page.loadEventFired",
+// "Network.responseReceived") and returns a channel of raw event params.
+// The channel is closed when the driver is closed.
+func (d *SessionDriver) On(method string) <-chan json.RawMessage {
+	ch := make(chan json.RawMessage, 16)
+	d.eventsMu.Lock()
+	d.events[method] = append(d.events[method], ch)
+	d.eventsMu.Unlock()
+	go func() {
+		<-d.closed
+		d.eventsMu.Lock()
+		defer d.eventsMu.Unlock()
+		close(ch)
+	}()
+	return ch
+}
+
+// Call invokes a CDP method and decodes its result into out (which may be
+// nil). It shares deadline/cancellation semantics with ctx: closing
+// ctx.Done() tears down the in-flight call without leaking the waiting
+// goroutine, using a cancel channel armed by a time.AfterFunc timer rather
+// than blocking forever on the response.
+func (d *SessionDriver) Call(ctx context.Context, method string, params interface{}, out interface{}) error {
+	id := atomic.AddInt64(&d.nextID, 1)
+
+	var rawParams json.RawMessage
+	if params != nil {
+		p, err := json.Marshal(params)
+		if err != nil {
+			return fmt.Errorf("cdp call %s: marshal params: %w", method, err)
+		}
+		rawParams = p
+	}
+
+	respCh := make(chan cdpResult, 1)
+	d.pending.Store(id, respCh)
+	defer d.pending.Delete(id)
+
+	d.writeMu.Lock()
+	err := d.conn.WriteJSON(cdpMessage{ID: id, Method: method, Params: rawParams})
+	d.writeMu.Unlock()
+	if err != nil {
+		return fmt.Errorf("cdp call %s: %w", method, err)
+	}
+
+	cancelled := make(chan struct{})
+	var timer *time.Timer
+	if deadline, ok := ctx.Deadline(); ok {
+		timer = time.AfterFunc(time.Until(deadline), func() { close(cancelled) })
+	}
+	if timer != nil {
+		defer timer.Stop()
+	}
+
+	select {
+	case res := <-respCh:
+		if res.err != nil {
+			return res.err
+		}
+		if out != nil && len(res.result) > 0 {
+			return json.Unmarshal(res.result, out)
+		}
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-cancelled:
+		return fmt.Errorf("cdp call %s: deadline exceeded", method)
+	case <-d.closed:
+		return fmt.Errorf("cdp call %s: connection closed", method)
+	}
+}
+
+// Navigate navigates the page to url and waits for the CDP command to be
+// acknowledged (not for the page load to finish — use On("Page.loadEventFired")
+// for that).
+func (d *SessionDriver) Navigate(ctx context.Context, url string) error {
+	var out struct {
+		FrameID string `json:"frameId"`
+	}
+	return d.Call(ctx, "Page.navigate", map[string]string{"url": url}, &out)
+}
+
+// Eval evaluates a JavaScript expression in the page context.
+func (d *SessionDriver) Eval(ctx context.Context, expression string) (json.RawMessage, error) {
+	var out struct {
+		Result json.RawMessage `json:"result"`
+	}
+	params := map[string]interface{}{"expression": expression, "returnByValue": true}
+	if err := d.Call(ctx, "Runtime.evaluate", params, &out); err != nil {
+		return nil, err
+	}
+	return out.Result, nil
+}
+
+// Screenshot captures the current page as a PNG.
+func (d *SessionDriver) Screenshot(ctx context.Context) ([]byte, error) {
+	var out struct {
+		Data string `json:"data"`
+	}
+	if err := d.Call(ctx, "Page.captureScreenshot", map[string]string{"format": "png"}, &out); err != nil {
+		return nil, fmt.Errorf("screenshot: %w", err)
+	}
+	return base64.StdEncoding.DecodeString(out.Data)
+}
+
+// SetRequestInterception enables or disables network request interception
+// via Fetch.enable/disable. Intercepted requests arrive on
+// On("Fetch.requestPaused") and must be resolved with ContinueRequest, or
+// with a raw Fetch.fulfillRequest / Fetch.failRequest sent via Call.
+func (d *SessionDriver) SetRequestInterception(ctx context.Context, enabled bool) error {
+	if !enabled {
+		return d.Call(ctx, "Fetch.disable", nil, nil)
+	}
+	return d.Call(ctx, "Fetch.enable", map[string]interface{}{}, nil)
+}
+
+// ContinueRequest resumes a paused request unmodified.
+func (d *SessionDriver) ContinueRequest(ctx context.Context, requestID string) error {
+	return d.Call(ctx, "Fetch.continueRequest", map[string]string{"requestId": requestID}, nil)
+}
+
+// QuerySelector returns the CDP node ID of the first element matching a CSS
+// selector within the document, or "" if none matches.
+func (d *SessionDriver) QuerySelector(ctx context.Context, selector string) (string, error) {
+	var doc struct {
+		Root struct {
+			NodeID int `json:"nodeId"`
+		} `json:"root"`
+	}
+	if err := d.Call(ctx, "DOM.getDocument", nil, &doc); err != nil {
+		return "", err
+	}
+	var node struct {
+		NodeID int `json:"nodeId"`
+	}
+	params := map[string]interface{}{"nodeId": doc.Root.NodeID, "selector": selector}
+	if err := d.Call(ctx, "DOM.querySelector", params, &node); err != nil {
+		return "", err
+	}
+	if node.NodeID == 0 {
+		return "", nil
+	}
+	return fmt.Sprintf("%d", node.NodeID), nil
+}
+
+// Close tears down the CDP connection and closes all event subscriptions.
+func (d *SessionDriver) Close() error {
+	var err error
+	d.closeOnce.Do(func() {
+		err = d.conn.Close()
+	})
+	return err
+}
@@ -32,9 +32,12 @@ import (
 
 // Client is the main client for interacting with the UndetectBrowser API.
 type Client struct {
-	baseURL    string
-	apiKey     string
-	httpClient *http.Client
+	baseURL        string
+	apiKey         string
+	httpClient     *http.Client
+	warningHandler func([]string)
+	retryPolicy    *RetryPolicy
+	authenticator  Authenticator
 
 	// API Resources
 	Profiles  *ProfilesService
@@ -46,16 +49,27 @@ type Client struct {
 // ClientOption is a function that configures the client.
 type ClientOption func(*Client)
 
-// WithAPIKey sets the API key for authentication.
+// WithAPIKey sets the API key for authentication, sent as a bearer token.
+// For mTLS, token refresh, or other auth schemes, use WithAuthenticator
+// instead.
 func WithAPIKey(apiKey string) ClientOption {
 	return func(c *Client) {
 		c.apiKey = apiKey
+		c.authenticator = apiKeyAuthenticator{apiKey: apiKey}
 	}
 }
 
-// WithHTTPClient sets a custom HTTP client.
+// WithHTTPClient sets a custom HTTP client. If httpClient.Transport is nil
+// (the zero value, meaning "use http.DefaultTransport"), any transport
+// already configured on the client via WithMTLS or WithTransport is
+// carried over, so this option can be combined with either regardless of
+// option order. To fully replace the transport too, set
+// httpClient.Transport explicitly before passing it in.
 func WithHTTPClient(httpClient *http.Client) ClientOption {
 	return func(c *Client) {
+		if httpClient.Transport == nil {
+			httpClient.Transport = c.httpClient.Transport
+		}
 		c.httpClient = httpClient
 	}
 }
@@ -67,6 +81,17 @@ func WithTimeout(timeout time.Duration) ClientOption {
 	}
 }
 
+// WithWarningHandler registers a callback invoked with any non-fatal
+// warnings (e.g. deprecated fingerprint fields, degraded proxy pools,
+// quota nearing exhaustion) returned alongside a successful response. The
+// handler runs synchronously on the goroutine making the call, after the
+// response has been parsed.
+func WithWarningHandler(handler func([]string)) ClientOption {
+	return func(c *Client) {
+		c.warningHandler = handler
+	}
+}
+
 // NewClient creates a new UndetectBrowser API client.
 func NewClient(baseURL string, opts ...ClientOption) *Client {
 	// Normalize base URL
@@ -97,14 +122,111 @@ func NewClient(baseURL string, opts ...ClientOption) *Client {
 
 // APIResponse represents a generic API response.
 type APIResponse struct {
-	Success bool            `json:"success"`
-	Data    json.RawMessage `json:"data,omitempty"`
-	Error   string          `json:"error,omitempty"`
-	Message string          `json:"message,omitempty"`
+	Success  bool            `json:"success"`
+	Data     json.RawMessage `json:"data,omitempty"`
+	Error    string          `json:"error,omitempty"`
+	Message  string          `json:"message,omitempty"`
+	Warnings []string        `json:"warnings,omitempty"`
+}
+
+// warningsKey is the context key under which WithWarningsCapture stashes
+// the slot a call's warnings get written into. A Client is shared across
+// goroutines, so warnings can't live on the Client itself without one
+// call's warnings racing with (or clobbering) another's; routing them
+// through the caller's own context keeps each call's warnings private to
+// that call.
+type warningsKey struct{}
+
+// WithWarningsCapture returns a context derived from ctx that captures any
+// non-fatal warnings returned by API calls made with it. Call
+// WarningsFromContext with the same context afterward to retrieve them:
+//
+//	ctx = antidetect.WithWarningsCapture(ctx)
+//	profile, err := client.Profiles.Create(ctx, opts)
+//	warnings := antidetect.WarningsFromContext(ctx)
+func WithWarningsCapture(ctx context.Context) context.Context {
+	return context.WithValue(ctx, warningsKey{}, new([]string))
+}
+
+// WarningsFromContext returns the warnings captured by a request made
+// with a context from WithWarningsCapture, or nil if ctx wasn't derived
+// from one (or no call has been made with it yet).
+func WarningsFromContext(ctx context.Context) []string {
+	slot, ok := ctx.Value(warningsKey{}).(*[]string)
+	if !ok {
+		return nil
+	}
+	return *slot
+}
+
+// deliverWarnings records warnings for any WithWarningsCapture slot on
+// ctx and, if set, invokes the client-wide WithWarningHandler.
+func (c *Client) deliverWarnings(ctx context.Context, warnings []string) {
+	if slot, ok := ctx.Value(warningsKey{}).(*[]string); ok {
+		*slot = warnings
+	}
+	if len(warnings) > 0 && c.warningHandler != nil {
+		c.warningHandler(warnings)
+	}
 }
 
-// request makes an HTTP request to the API.
+// request makes an HTTP request to the API, retrying according to
+// c.retryPolicy if one was configured via WithRetry.
 func (c *Client) request(ctx context.Context, method, path string, body interface{}, result interface{}) error {
+	if c.retryPolicy == nil {
+		return c.doRequest(ctx, method, path, body, result)
+	}
+
+	policy := c.retryPolicy
+	var err error
+	for attempt := 1; attempt <= policy.MaxAttempts; attempt++ {
+		err = c.doRequest(ctx, method, path, body, result)
+		if err == nil {
+			return nil
+		}
+		if attempt == policy.MaxAttempts || !policy.retryable(err, method) {
+			return err
+		}
+
+		delay := policy.backoff(attempt)
+		if rle, ok := err.(*RateLimitError); ok && rle.RetryAfter > 0 {
+			if retryAfter := time.Duration(rle.RetryAfter) * time.Second; retryAfter > delay {
+				delay = retryAfter
+			}
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+	return err
+}
+
+// doRequest performs a single HTTP request/response cycle against the
+// API, transparently refreshing and retrying once if the authenticator
+// supports it and the server responds 401.
+func (c *Client) doRequest(ctx context.Context, method, path string, body interface{}, result interface{}) error {
+	err := c.doRequestOnce(ctx, method, path, body, result)
+	if _, is401 := err.(*AuthenticationError); !is401 {
+		return err
+	}
+	refresher, ok := c.authenticator.(RefreshableAuthenticator)
+	if !ok {
+		return err
+	}
+	if refreshErr := refresher.Refresh(ctx); refreshErr != nil {
+		return err
+	}
+	return c.doRequestOnce(ctx, method, path, body, result)
+}
+
+// doRequestOnce performs a single HTTP request/response cycle against the
+// API with no retry of any kind.
+func (c *Client) doRequestOnce(ctx context.Context, method, path string, body interface{}, result interface{}) error {
 	var bodyReader io.Reader
 	if body != nil {
 		jsonBody, err := json.Marshal(body)
@@ -121,8 +243,10 @@ func (c *Client) request(ctx context.Context, method, path string, body interfac
 
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
-	if c.apiKey != "" {
-		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	if c.authenticator != nil {
+		if err := c.authenticator.Apply(req); err != nil {
+			return fmt.Errorf("failed to apply authenticator: %w", err)
+		}
 	}
 
 	resp, err := c.httpClient.Do(req)
@@ -138,7 +262,7 @@ func (c *Client) request(ctx context.Context, method, path string, body interfac
 
 	// Handle HTTP errors
 	if resp.StatusCode >= 400 {
-		return c.handleErrorResponse(resp.StatusCode, respBody)
+		return c.handleErrorResponse(resp.StatusCode, respBody, resp.Header)
 	}
 
 	// Parse response
@@ -151,6 +275,8 @@ func (c *Client) request(ctx context.Context, method, path string, body interfac
 		return nil
 	}
 
+	c.deliverWarnings(ctx, apiResp.Warnings)
+
 	if !apiResp.Success && apiResp.Error != "" {
 		return &Error{
 			Message:    apiResp.Error,
@@ -165,7 +291,12 @@ func (c *Client) request(ctx context.Context, method, path string, body interfac
 	return nil
 }
 
-func (c *Client) handleErrorResponse(statusCode int, body []byte) error {
+func (c *Client) handleErrorResponse(statusCode int, body []byte, header http.Header) error {
+	var retryAfter int
+	if secs, ok := parseRetryAfter(header.Get("Retry-After")); ok {
+		retryAfter = secs
+	}
+
 	var apiResp APIResponse
 	if err := json.Unmarshal(body, &apiResp); err == nil && apiResp.Error != "" {
 		switch statusCode {
@@ -176,7 +307,7 @@ func (c *Client) handleErrorResponse(statusCode int, body []byte) error {
 		case 400:
 			return &ValidationError{Message: apiResp.Error}
 		case 429:
-			return &RateLimitError{Message: apiResp.Error}
+			return &RateLimitError{Message: apiResp.Error, RetryAfter: retryAfter}
 		default:
 			return &Error{Message: apiResp.Error, StatusCode: statusCode}
 		}
@@ -190,7 +321,7 @@ func (c *Client) handleErrorResponse(statusCode int, body []byte) error {
 	case 400:
 		return &ValidationError{Message: string(body)}
 	case 429:
-		return &RateLimitError{Message: "rate limit exceeded"}
+		return &RateLimitError{Message: "rate limit exceeded", RetryAfter: retryAfter}
 	default:
 		return &Error{Message: string(body), StatusCode: statusCode}
 	}
@@ -390,10 +521,55 @@ type LaunchOptions struct {
 	Timeout  int      `json:"timeout,omitempty"`
 }
 
-// ListOptions represents options for list operations.
+// ListOptions represents options for list operations, including
+// server-side filters. Zero values are omitted from the request.
 type ListOptions struct {
 	Page  int `json:"page,omitempty"`
 	Limit int `json:"limit,omitempty"`
+
+	Status       string    `json:"status,omitempty"`
+	OS           string    `json:"os,omitempty"`
+	Browser      string    `json:"browser,omitempty"`
+	CreatedAfter time.Time `json:"createdAfter,omitempty"`
+	Search       string    `json:"search,omitempty"`
+}
+
+// queryValues encodes o as URL query parameters.
+func (o *ListOptions) queryValues() url.Values {
+	params := url.Values{}
+	if o == nil {
+		return params
+	}
+	if o.Page > 0 {
+		params.Set("page", fmt.Sprintf("%d", o.Page))
+	}
+	if o.Limit > 0 {
+		params.Set("limit", fmt.Sprintf("%d", o.Limit))
+	}
+	if o.Status != "" {
+		params.Set("status", o.Status)
+	}
+	if o.OS != "" {
+		params.Set("os", o.OS)
+	}
+	if o.Browser != "" {
+		params.Set("browser", o.Browser)
+	}
+	if !o.CreatedAfter.IsZero() {
+		params.Set("createdAfter", o.CreatedAfter.Format(time.RFC3339))
+	}
+	if o.Search != "" {
+		params.Set("search", o.Search)
+	}
+	return params
+}
+
+// withQuery appends o's encoded query parameters to path, if any.
+func (o *ListOptions) withQuery(path string) string {
+	if params := o.queryValues(); len(params) > 0 {
+		return path + "?" + params.Encode()
+	}
+	return path
 }
 
 // =============================================================================
@@ -405,24 +581,10 @@ type ProfilesService struct {
 	client *Client
 }
 
-// List returns all profiles.
+// List returns profiles matching opts (nil for the default page).
 func (s *ProfilesService) List(ctx context.Context, opts *ListOptions) ([]Profile, error) {
-	path := "/profiles"
-	if opts != nil {
-		params := url.Values{}
-		if opts.Page > 0 {
-			params.Set("page", fmt.Sprintf("%d", opts.Page))
-		}
-		if opts.Limit > 0 {
-			params.Set("limit", fmt.Sprintf("%d", opts.Limit))
-		}
-		if len(params) > 0 {
-			path += "?" + params.Encode()
-		}
-	}
-
 	var result []Profile
-	err := s.client.request(ctx, http.MethodGet, path, nil, &result)
+	err := s.client.request(ctx, http.MethodGet, opts.withQuery("/profiles"), nil, &result)
 	return result, err
 }
 
@@ -457,10 +619,10 @@ type SessionsService struct {
 	client *Client
 }
 
-// List returns all sessions.
-func (s *SessionsService) List(ctx context.Context) ([]Session, error) {
+// List returns sessions matching opts (nil for the default page).
+func (s *SessionsService) List(ctx context.Context, opts *ListOptions) ([]Session, error) {
 	var result []Session
-	err := s.client.request(ctx, http.MethodGet, "/sessions", nil, &result)
+	err := s.client.request(ctx, http.MethodGet, opts.withQuery("/sessions"), nil, &result)
 	return result, err
 }
 
@@ -507,10 +669,10 @@ type ProxiesService struct {
 	client *Client
 }
 
-// List returns all proxies.
-func (s *ProxiesService) List(ctx context.Context) ([]Proxy, error) {
+// List returns proxies matching opts (nil for the default page).
+func (s *ProxiesService) List(ctx context.Context, opts *ListOptions) ([]Proxy, error) {
 	var result []Proxy
-	err := s.client.request(ctx, http.MethodGet, "/proxies", nil, &result)
+	err := s.client.request(ctx, http.MethodGet, opts.withQuery("/proxies"), nil, &result)
 	return result, err
 }
 
@@ -0,0 +1,54 @@
+package antidetect
+
+import (
+	"context"
+	"testing"
+)
+
+func TestWarningsFromContextWithoutCapture(t *testing.T) {
+	if got := WarningsFromContext(context.Background()); got != nil {
+		t.Fatalf("WarningsFromContext(plain ctx) = %v, want nil", got)
+	}
+}
+
+func TestDeliverWarningsPopulatesCapturedContext(t *testing.T) {
+	ctx := WithWarningsCapture(context.Background())
+	c := &Client{}
+
+	c.deliverWarnings(ctx, []string{"quota nearing exhaustion"})
+
+	got := WarningsFromContext(ctx)
+	want := []string{"quota nearing exhaustion"}
+	if len(got) != len(want) || got[0] != want[0] {
+		t.Fatalf("WarningsFromContext() = %v, want %v", got, want)
+	}
+}
+
+func TestDeliverWarningsInvokesHandler(t *testing.T) {
+	var got []string
+	c := &Client{warningHandler: func(w []string) { got = w }}
+
+	c.deliverWarnings(context.Background(), []string{"deprecated field"})
+
+	if len(got) != 1 || got[0] != "deprecated field" {
+		t.Fatalf("warningHandler received %v, want [\"deprecated field\"]", got)
+	}
+}
+
+func TestDeliverWarningsKeepsSeparateContextsIndependent(t *testing.T) {
+	c := &Client{}
+	ctxA := WithWarningsCapture(context.Background())
+	ctxB := WithWarningsCapture(context.Background())
+
+	c.deliverWarnings(ctxA, []string{"from A"})
+	c.deliverWarnings(ctxB, []string{"from B"})
+
+	gotA := WarningsFromContext(ctxA)
+	gotB := WarningsFromContext(ctxB)
+	if len(gotA) != 1 || gotA[0] != "from A" {
+		t.Fatalf("WarningsFromContext(ctxA) = %v, want [\"from A\"]", gotA)
+	}
+	if len(gotB) != 1 || gotB[0] != "from B" {
+		t.Fatalf("WarningsFromContext(ctxB) = %v, want [\"from B\"]", gotB)
+	}
+}
@@ -0,0 +1,137 @@
+package antidetect
+
+import "context"
+
+// PageInfo describes the page most recently fetched by an Iterator.
+//
+// The list endpoints this SDK talks to return a flat array with no
+// pagination metadata, so Total is always -1 (unknown) and HasMore is
+// inferred by requesting Limit items and checking whether a full page
+// came back. Set ListOptions.Limit when iterating to get a useful
+// HasMore signal; with the default (zero) limit, HasMore is always false
+// and Iterate stops after the first non-empty page.
+type PageInfo struct {
+	Total   int
+	Page    int
+	HasMore bool
+}
+
+// Iterator walks a paginated list endpoint one item at a time, fetching
+// subsequent pages as needed. It is not safe for concurrent use.
+type Iterator[T any] struct {
+	fetch func(ctx context.Context, page int) ([]T, PageInfo, error)
+
+	page  int
+	items []T
+	idx   int
+	cur   T
+	info  PageInfo
+	err   error
+	done  bool
+}
+
+func newIterator[T any](fetch func(ctx context.Context, page int) ([]T, PageInfo, error)) *Iterator[T] {
+	return &Iterator[T]{fetch: fetch, page: 1}
+}
+
+// Next advances the iterator and reports whether a value is available via
+// Value. It returns false once the last page has been consumed or a
+// fetch fails; callers should check Err to distinguish the two.
+func (it *Iterator[T]) Next(ctx context.Context) bool {
+	if it.err != nil || it.done {
+		return false
+	}
+	for it.idx >= len(it.items) {
+		if it.page > 1 && !it.info.HasMore {
+			it.done = true
+			return false
+		}
+		items, info, err := it.fetch(ctx, it.page)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.items, it.info, it.idx = items, info, 0
+		it.page++
+		if len(items) == 0 {
+			it.done = true
+			return false
+		}
+	}
+	it.cur = it.items[it.idx]
+	it.idx++
+	return true
+}
+
+// Value returns the item at the iterator's current position. It is only
+// valid after a call to Next that returned true.
+func (it *Iterator[T]) Value() T {
+	return it.cur
+}
+
+// Err returns the error, if any, that caused Next to return false.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// Page returns pagination metadata for the most recently fetched page.
+func (it *Iterator[T]) Page() PageInfo {
+	return it.info
+}
+
+// Iterate returns an Iterator over profiles matching filter, transparently
+// fetching subsequent pages via List. See PageInfo for the caveats that
+// come with this endpoint's flat-array response.
+func (s *ProfilesService) Iterate(ctx context.Context, filter ListOptions) *Iterator[Profile] {
+	return newIterator(func(ctx context.Context, page int) ([]Profile, PageInfo, error) {
+		opts := filter
+		opts.Page = page
+		items, err := s.List(ctx, &opts)
+		if err != nil {
+			return nil, PageInfo{}, err
+		}
+		return items, pageInfoFor(page, opts.Limit, len(items)), nil
+	})
+}
+
+// Iterate returns an Iterator over sessions matching filter, transparently
+// fetching subsequent pages via List. See PageInfo for the caveats that
+// come with this endpoint's flat-array response.
+func (s *SessionsService) Iterate(ctx context.Context, filter ListOptions) *Iterator[Session] {
+	return newIterator(func(ctx context.Context, page int) ([]Session, PageInfo, error) {
+		opts := filter
+		opts.Page = page
+		items, err := s.List(ctx, &opts)
+		if err != nil {
+			return nil, PageInfo{}, err
+		}
+		return items, pageInfoFor(page, opts.Limit, len(items)), nil
+	})
+}
+
+// Iterate returns an Iterator over proxies matching filter, transparently
+// fetching subsequent pages via List. See PageInfo for the caveats that
+// come with this endpoint's flat-array response.
+func (s *ProxiesService) Iterate(ctx context.Context, filter ListOptions) *Iterator[Proxy] {
+	return newIterator(func(ctx context.Context, page int) ([]Proxy, PageInfo, error) {
+		opts := filter
+		opts.Page = page
+		items, err := s.List(ctx, &opts)
+		if err != nil {
+			return nil, PageInfo{}, err
+		}
+		return items, pageInfoFor(page, opts.Limit, len(items)), nil
+	})
+}
+
+// pageInfoFor builds a PageInfo for a page fetched with the given limit
+// and returned item count. Total is unknown (-1) because the flat-array
+// list endpoints don't report it; HasMore is true only when a full page
+// was returned, since a short page conclusively means there's nothing left.
+func pageInfoFor(page, limit, count int) PageInfo {
+	return PageInfo{
+		Total:   -1,
+		Page:    page,
+		HasMore: limit > 0 && count == limit,
+	}
+}
@@ -0,0 +1,98 @@
+package antidetect
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestPageInfoFor(t *testing.T) {
+	tests := []struct {
+		name        string
+		page, limit int
+		count       int
+		wantMore    bool
+	}{
+		{name: "full page with limit set", page: 1, limit: 10, count: 10, wantMore: true},
+		{name: "short page with limit set", page: 2, limit: 10, count: 3, wantMore: false},
+		{name: "empty page with limit set", page: 3, limit: 10, count: 0, wantMore: false},
+		{name: "no limit never reports more", page: 1, limit: 0, count: 50, wantMore: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := pageInfoFor(tt.page, tt.limit, tt.count)
+			if info.Total != -1 {
+				t.Fatalf("Total = %d, want -1 (unknown)", info.Total)
+			}
+			if info.Page != tt.page {
+				t.Fatalf("Page = %d, want %d", info.Page, tt.page)
+			}
+			if info.HasMore != tt.wantMore {
+				t.Fatalf("HasMore = %v, want %v", info.HasMore, tt.wantMore)
+			}
+		})
+	}
+}
+
+func TestIteratorNextWalksAllPages(t *testing.T) {
+	pages := [][]int{{1, 2}, {3, 4}, {5}}
+	calls := 0
+	it := newIterator(func(ctx context.Context, page int) ([]int, PageInfo, error) {
+		calls++
+		if page > len(pages) {
+			return nil, PageInfo{}, nil
+		}
+		items := pages[page-1]
+		return items, pageInfoFor(page, 2, len(items)), nil
+	})
+
+	var got []int
+	for it.Next(context.Background()) {
+		got = append(got, it.Value())
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("unexpected Err(): %v", err)
+	}
+	want := []int{1, 2, 3, 4, 5}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	}
+	if calls != 3 {
+		t.Fatalf("fetch called %d times, want 3 (stops once a short page signals no more)", calls)
+	}
+}
+
+func TestIteratorNextStopsOnEmptyFirstPage(t *testing.T) {
+	it := newIterator(func(ctx context.Context, page int) ([]int, PageInfo, error) {
+		return nil, PageInfo{}, nil
+	})
+	if it.Next(context.Background()) {
+		t.Fatal("Next() = true on an empty first page, want false")
+	}
+	if it.Err() != nil {
+		t.Fatalf("unexpected Err(): %v", it.Err())
+	}
+}
+
+func TestIteratorNextPropagatesFetchError(t *testing.T) {
+	wantErr := errors.New("boom")
+	it := newIterator(func(ctx context.Context, page int) ([]int, PageInfo, error) {
+		return nil, PageInfo{}, wantErr
+	})
+	if it.Next(context.Background()) {
+		t.Fatal("Next() = true after a failing fetch, want false")
+	}
+	if it.Err() != wantErr {
+		t.Fatalf("Err() = %v, want %v", it.Err(), wantErr)
+	}
+	// Once failed, subsequent calls stay failed rather than retrying.
+	if it.Next(context.Background()) {
+		t.Fatal("Next() = true after iterator already failed, want false")
+	}
+}
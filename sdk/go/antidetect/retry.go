@@ -0,0 +1,120 @@
+package antidetect
+
+import (
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy configures automatic retries for transient failures.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first
+	// one. A value <= 1 disables retries.
+	MaxAttempts int
+	// BaseDelay is the delay before the first retry; subsequent retries
+	// back off exponentially from it.
+	BaseDelay time.Duration
+	// MaxDelay caps the backoff delay, before jitter is applied.
+	MaxDelay time.Duration
+	// Retryable decides whether a given error from a given HTTP method
+	// should be retried. If nil, defaultRetryable is used, which retries
+	// network errors, 502/503/504, and 429 (honoring Retry-After) on
+	// idempotent methods (GET, HEAD, PUT, DELETE) only.
+	Retryable func(err error, method string) bool
+}
+
+// DefaultRetryPolicy returns a conservative policy: 3 attempts, starting at
+// 500ms and doubling up to 10s, retrying only idempotent methods.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    10 * time.Second,
+	}
+}
+
+// WithRetry enables automatic retries with exponential backoff and jitter
+// for requests that fail with a transient error (network errors, 502,
+// 503, 504) or are rate limited (429), honoring the Retry-After header of
+// a 429 response. Retry-After on other statuses is not read.
+func WithRetry(policy RetryPolicy) ClientOption {
+	return func(c *Client) {
+		if policy.MaxAttempts <= 0 {
+			policy.MaxAttempts = 1
+		}
+		c.retryPolicy = &policy
+	}
+}
+
+func (p *RetryPolicy) retryable(err error, method string) bool {
+	if p.Retryable != nil {
+		return p.Retryable(err, method)
+	}
+	return defaultRetryable(err, method)
+}
+
+func defaultRetryable(err error, method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+	default:
+		return false
+	}
+
+	if _, ok := err.(*RateLimitError); ok {
+		return true
+	}
+	if e, ok := err.(*Error); ok {
+		switch e.StatusCode {
+		case 502, 503, 504:
+			return true
+		}
+		return false
+	}
+	switch err.(type) {
+	case *AuthenticationError, *NotFoundError, *ValidationError:
+		// Deterministic client errors (401/404/400): retrying will not
+		// change the outcome, so never treat them as transient.
+		return false
+	}
+	// Anything else (connection refused, timeout, DNS failure, ...) is
+	// treated as a transient network error.
+	return true
+}
+
+// backoff computes the delay before attempt n (1-indexed retry count),
+// applying full jitter in [0, delay).
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	delay := float64(p.BaseDelay) * math.Pow(2, float64(attempt-1))
+	if max := float64(p.MaxDelay); p.MaxDelay > 0 && delay > max {
+		delay = max
+	}
+	if delay <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(delay)))
+}
+
+// parseRetryAfter parses a Retry-After header in either delta-seconds
+// ("120") or HTTP-date ("Fri, 31 Dec 2026 23:59:59 GMT") form, returning
+// the number of seconds to wait.
+func parseRetryAfter(header string) (int, bool) {
+	if header == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(header); err == nil {
+		if secs < 0 {
+			secs = 0
+		}
+		return secs, true
+	}
+	if t, err := http.ParseTime(header); err == nil {
+		wait := time.Until(t)
+		if wait < 0 {
+			wait = 0
+		}
+		return int(math.Ceil(wait.Seconds())), true
+	}
+	return 0, false
+}
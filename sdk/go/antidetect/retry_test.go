@@ -0,0 +1,109 @@
+package antidetect
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		name    string
+		header  string
+		wantOK  bool
+		wantMin int
+		wantMax int
+	}{
+		{name: "empty", header: "", wantOK: false},
+		{name: "delta seconds", header: "120", wantOK: true, wantMin: 120, wantMax: 120},
+		{name: "zero", header: "0", wantOK: true, wantMin: 0, wantMax: 0},
+		{name: "negative delta seconds clamps to zero", header: "-5", wantOK: true, wantMin: 0, wantMax: 0},
+		{name: "garbage", header: "not-a-date", wantOK: false},
+		{
+			name:    "http date in the future",
+			header:  time.Now().Add(90 * time.Second).UTC().Format(http.TimeFormat),
+			wantOK:  true,
+			wantMin: 85,
+			wantMax: 91,
+		},
+		{
+			name:    "http date in the past clamps to zero",
+			header:  time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat),
+			wantOK:  true,
+			wantMin: 0,
+			wantMax: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secs, ok := parseRetryAfter(tt.header)
+			if ok != tt.wantOK {
+				t.Fatalf("parseRetryAfter(%q) ok = %v, want %v", tt.header, ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if secs < tt.wantMin || secs > tt.wantMax {
+				t.Fatalf("parseRetryAfter(%q) = %d, want in [%d, %d]", tt.header, secs, tt.wantMin, tt.wantMax)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+
+	for attempt := 1; attempt <= 6; attempt++ {
+		delay := policy.backoff(attempt)
+		if delay < 0 {
+			t.Fatalf("backoff(%d) = %v, want >= 0", attempt, delay)
+		}
+		if delay > policy.MaxDelay {
+			t.Fatalf("backoff(%d) = %v, want <= MaxDelay %v", attempt, delay, policy.MaxDelay)
+		}
+	}
+}
+
+func TestRetryPolicyBackoffZeroBaseDelay(t *testing.T) {
+	policy := RetryPolicy{}
+	if delay := policy.backoff(1); delay != 0 {
+		t.Fatalf("backoff(1) with zero BaseDelay = %v, want 0", delay)
+	}
+}
+
+func TestDefaultRetryable(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		method string
+		want   bool
+	}{
+		{name: "rate limit on GET retries", err: &RateLimitError{}, method: http.MethodGet, want: true},
+		{name: "rate limit on POST does not retry", err: &RateLimitError{}, method: http.MethodPost, want: false},
+		{name: "502 on GET retries", err: &Error{StatusCode: 502}, method: http.MethodGet, want: true},
+		{name: "503 on DELETE retries", err: &Error{StatusCode: 503}, method: http.MethodDelete, want: true},
+		{name: "504 on PUT retries", err: &Error{StatusCode: 504}, method: http.MethodPut, want: true},
+		{name: "500 on GET does not retry", err: &Error{StatusCode: 500}, method: http.MethodGet, want: false},
+		{name: "401 on GET does not retry", err: &AuthenticationError{}, method: http.MethodGet, want: false},
+		{name: "404 on GET does not retry", err: &NotFoundError{}, method: http.MethodGet, want: false},
+		{name: "400 on PUT does not retry", err: &ValidationError{}, method: http.MethodPut, want: false},
+		{name: "network error on GET retries", err: errConnRefused, method: http.MethodGet, want: true},
+		{name: "network error on POST does not retry", err: errConnRefused, method: http.MethodPost, want: false},
+		{name: "network error on PATCH does not retry", err: errConnRefused, method: http.MethodPatch, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := defaultRetryable(tt.err, tt.method); got != tt.want {
+				t.Fatalf("defaultRetryable(%v, %s) = %v, want %v", tt.err, tt.method, got, tt.want)
+			}
+		})
+	}
+}
+
+type testError struct{ msg string }
+
+func (e *testError) Error() string { return e.msg }
+
+var errConnRefused = &testError{msg: "connection refused"}
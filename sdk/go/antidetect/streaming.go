@@ -0,0 +1,256 @@
+package antidetect
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// SessionEvent is a single event delivered by SessionsService.Stream.
+type SessionEvent struct {
+	// ID is the event's cursor position, usable as LastEventID to resume
+	// a stream after a disconnect or process restart.
+	ID   string          `json:"id"`
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// NavigationEvent is the decoded Data payload of a SessionEvent with
+// Type == "navigation".
+type NavigationEvent struct {
+	URL    string `json:"url"`
+	Status int    `json:"status"`
+}
+
+// DetectionScoreChanged is the decoded Data payload of a SessionEvent with
+// Type == "detection_score_changed".
+type DetectionScoreChanged struct {
+	Score   float64            `json:"score"`
+	Details map[string]float64 `json:"details,omitempty"`
+}
+
+// ProxyRotated is the decoded Data payload of a SessionEvent with
+// Type == "proxy_rotated".
+type ProxyRotated struct {
+	ProxyID string `json:"proxyId"`
+	Country string `json:"country,omitempty"`
+}
+
+// SessionEnded is the decoded Data payload of a SessionEvent with
+// Type == "session_ended".
+type SessionEnded struct {
+	Reason string `json:"reason"`
+}
+
+// AnalyticsEvent is a single event delivered by AnalyticsService.Subscribe.
+type AnalyticsEvent struct {
+	ID   string          `json:"id"`
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// AnalyticsFilter narrows which events AnalyticsService.Subscribe delivers.
+type AnalyticsFilter struct {
+	ProfileID string
+	Types     []string
+}
+
+// Reconnect backoff bounds shared by Stream and Subscribe.
+const (
+	streamReconnectBaseDelay = 500 * time.Millisecond
+	streamReconnectMaxDelay  = 30 * time.Second
+)
+
+// Stream opens a long-lived connection to /sessions/:id/events and
+// delivers SessionEvent values as they occur. The returned channel is
+// closed when ctx is done or the session ends; the connection transparently
+// reconnects with backoff on transient disconnects, resuming from the last
+// delivered event's ID.
+//
+// lastEventID resumes the stream from a previously observed SessionEvent.ID
+// (e.g. one persisted before a process restart); pass "" to start from the
+// server's current position.
+func (s *SessionsService) Stream(ctx context.Context, sessionID, lastEventID string) (<-chan SessionEvent, error) {
+	wsURL, err := s.client.wsURL(fmt.Sprintf("/sessions/%s/events", sessionID))
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan SessionEvent)
+	go runEventStream(ctx, wsURL, lastEventID, func(raw json.RawMessage, lastEventID *string) bool {
+		var evt SessionEvent
+		if err := json.Unmarshal(raw, &evt); err != nil {
+			return true
+		}
+		if evt.ID != "" {
+			*lastEventID = evt.ID
+		}
+		select {
+		case out <- evt:
+			// session_ended is the last event a session produces.
+			return evt.Type != "session_ended"
+		case <-ctx.Done():
+			return false
+		}
+	}, out, nil)
+	return out, nil
+}
+
+// Subscribe opens a long-lived connection to /analytics/stream and
+// delivers AnalyticsEvent values matching filter as they occur. Like
+// Stream, it reconnects transparently with backoff on transient
+// disconnects.
+//
+// lastEventID resumes the stream from a previously observed
+// AnalyticsEvent.ID (e.g. one persisted before a process restart); pass ""
+// to start from the server's current position.
+func (s *AnalyticsService) Subscribe(ctx context.Context, filter AnalyticsFilter, lastEventID string) (<-chan AnalyticsEvent, error) {
+	path := "/analytics/stream"
+	params := url.Values{}
+	if filter.ProfileID != "" {
+		params.Set("profileId", filter.ProfileID)
+	}
+	if len(filter.Types) > 0 {
+		params.Set("types", strings.Join(filter.Types, ","))
+	}
+	if len(params) > 0 {
+		path += "?" + params.Encode()
+	}
+	wsURL, err := s.client.wsURL(path)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan AnalyticsEvent)
+	go runEventStream(ctx, wsURL, lastEventID, func(raw json.RawMessage, lastEventID *string) bool {
+		var evt AnalyticsEvent
+		if err := json.Unmarshal(raw, &evt); err != nil {
+			return true
+		}
+		if evt.ID != "" {
+			*lastEventID = evt.ID
+		}
+		select {
+		case out <- evt:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}, nil, out)
+	return out, nil
+}
+
+// wsURL rewrites the client's http(s) base URL into a ws(s) URL for the
+// given path.
+func (c *Client) wsURL(path string) (string, error) {
+	base := c.baseURL
+	switch {
+	case strings.HasPrefix(base, "https://"):
+		base = "wss://" + strings.TrimPrefix(base, "https://")
+	case strings.HasPrefix(base, "http://"):
+		base = "ws://" + strings.TrimPrefix(base, "http://")
+	default:
+		return "", fmt.Errorf("unrecognized base URL scheme: %s", base)
+	}
+	return base + path, nil
+}
+
+// runEventStream dials wsURL and feeds each received message to deliver
+// until ctx is done, reconnecting with exponential backoff and jitter on
+// transient disconnects and resuming with ?lastEventId= so the server can
+// replay anything missed. deliver returns false to stop the stream.
+func runEventStream(ctx context.Context, wsURL, lastEventID string, deliver func(raw json.RawMessage, lastEventID *string) bool, sessionOut chan<- SessionEvent, analyticsOut chan<- AnalyticsEvent) {
+	defer func() {
+		if sessionOut != nil {
+			close(sessionOut)
+		}
+		if analyticsOut != nil {
+			close(analyticsOut)
+		}
+	}()
+
+	attempt := 0
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+
+		dialURL := wsURL
+		if lastEventID != "" {
+			sep := "?"
+			if strings.Contains(dialURL, "?") {
+				sep = "&"
+			}
+			dialURL += sep + "lastEventId=" + lastEventID
+		}
+
+		dialer := websocket.Dialer{HandshakeTimeout: 10 * time.Second}
+		conn, _, err := dialer.DialContext(ctx, dialURL, nil)
+		if err != nil {
+			if !reconnectWait(ctx, &attempt) {
+				return
+			}
+			continue
+		}
+		attempt = 0
+
+		// watcherCtx scopes the closer goroutine to this connection
+		// attempt: cancel() stops it as soon as the read loop below exits
+		// on its own (the common case, a transient disconnect), instead
+		// of leaking a goroutine parked on <-ctx.Done() until the
+		// caller's ctx is eventually cancelled.
+		watcherCtx, cancel := context.WithCancel(ctx)
+		go func() {
+			<-watcherCtx.Done()
+			conn.Close()
+		}()
+
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				break
+			}
+			if !deliver(data, &lastEventID) {
+				cancel()
+				conn.Close()
+				return
+			}
+		}
+		cancel()
+
+		if ctx.Err() != nil {
+			return
+		}
+		if !reconnectWait(ctx, &attempt) {
+			return
+		}
+	}
+}
+
+// reconnectWait sleeps for an exponentially increasing, jittered delay
+// before the next reconnect attempt, returning false if ctx is cancelled
+// first.
+func reconnectWait(ctx context.Context, attempt *int) bool {
+	*attempt++
+	delay := float64(streamReconnectBaseDelay) * math.Pow(2, float64(*attempt-1))
+	if delay > float64(streamReconnectMaxDelay) {
+		delay = float64(streamReconnectMaxDelay)
+	}
+	jittered := time.Duration(delay/2) + time.Duration(rand.Int63n(int64(delay/2)+1))
+
+	timer := time.NewTimer(jittered)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}